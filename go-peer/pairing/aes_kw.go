@@ -0,0 +1,105 @@
+package pairing
+
+import (
+	"crypto/aes"
+	"encoding/binary"
+	"fmt"
+)
+
+// aesKeyWrapDefaultIV is the standard 8-byte integrity check value used by
+// the AES Key Wrap algorithm (RFC 3394 §2.2.3.1).
+var aesKeyWrapDefaultIV = []byte{0xA6, 0xA6, 0xA6, 0xA6, 0xA6, 0xA6, 0xA6, 0xA6}
+
+// aesKeyWrap wraps cek with kek using the AES Key Wrap algorithm (RFC 3394).
+func aesKeyWrap(kek, cek []byte) ([]byte, error) {
+	if len(cek)%8 != 0 || len(cek) < 16 {
+		return nil, fmt.Errorf("key to wrap must be a multiple of 8 bytes, at least 16")
+	}
+
+	block, err := aes.NewCipher(kek)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(cek) / 8
+	r := make([][]byte, n)
+	for i := 0; i < n; i++ {
+		r[i] = append([]byte{}, cek[i*8:(i+1)*8]...)
+	}
+
+	a := append([]byte{}, aesKeyWrapDefaultIV...)
+	buf := make([]byte, 16)
+	for j := 0; j <= 5; j++ {
+		for i := 0; i < n; i++ {
+			copy(buf[:8], a)
+			copy(buf[8:], r[i])
+			block.Encrypt(buf, buf)
+
+			t := uint64(n*j + i + 1)
+			tBytes := make([]byte, 8)
+			binary.BigEndian.PutUint64(tBytes, t)
+			for k := range a {
+				a[k] = buf[k] ^ tBytes[k]
+			}
+			r[i] = append([]byte{}, buf[8:]...)
+		}
+	}
+
+	wrapped := make([]byte, 0, len(cek)+8)
+	wrapped = append(wrapped, a...)
+	for i := 0; i < n; i++ {
+		wrapped = append(wrapped, r[i]...)
+	}
+	return wrapped, nil
+}
+
+// aesKeyUnwrap reverses aesKeyWrap, returning an error if the integrity
+// check fails.
+func aesKeyUnwrap(kek, wrapped []byte) ([]byte, error) {
+	if len(wrapped)%8 != 0 || len(wrapped) < 24 {
+		return nil, fmt.Errorf("wrapped key must be a multiple of 8 bytes, at least 24")
+	}
+
+	block, err := aes.NewCipher(kek)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(wrapped)/8 - 1
+	a := append([]byte{}, wrapped[:8]...)
+	r := make([][]byte, n)
+	for i := 0; i < n; i++ {
+		r[i] = append([]byte{}, wrapped[(i+1)*8:(i+2)*8]...)
+	}
+
+	buf := make([]byte, 16)
+	for j := 5; j >= 0; j-- {
+		for i := n - 1; i >= 0; i-- {
+			t := uint64(n*j + i + 1)
+			tBytes := make([]byte, 8)
+			binary.BigEndian.PutUint64(tBytes, t)
+
+			xored := make([]byte, 8)
+			for k := range a {
+				xored[k] = a[k] ^ tBytes[k]
+			}
+			copy(buf[:8], xored)
+			copy(buf[8:], r[i])
+			block.Decrypt(buf, buf)
+			a = append([]byte{}, buf[:8]...)
+			r[i] = append([]byte{}, buf[8:]...)
+		}
+	}
+
+	for i := range a {
+		if a[i] != aesKeyWrapDefaultIV[i] {
+			return nil, fmt.Errorf("key unwrap integrity check failed")
+		}
+	}
+
+	unwrapped := make([]byte, 0, n*8)
+	for i := 0; i < n; i++ {
+		unwrapped = append(unwrapped, r[i]...)
+	}
+	return unwrapped, nil
+}
@@ -0,0 +1,70 @@
+package pairing
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+type autoKeyOperations struct {
+	rand io.Reader
+}
+
+// Returns a KeyOperations which dispatches to the ECDSA, RSA, or Ed25519
+// implementation based on the JWK's "kty" (and "crv", for EC/OKP) members.
+// Keys generated via this KeyOperations are ECDSA P-256, matching
+// NewEcdsaKeyOperations.
+func NewAutoKeyOperations() KeyOperations {
+	return NewAutoKeyOperationsWithRand(rand.Reader)
+}
+
+func NewAutoKeyOperationsWithRand(rand io.Reader) KeyOperations {
+	return autoKeyOperations{rand}
+}
+
+func (a autoKeyOperations) importJwkPublicKey(jwk string) (PublicKey, error) {
+	keyOperations, err := keyOperationsForJwk(jwk, a.rand)
+	if err != nil {
+		return nil, err
+	}
+	return keyOperations.importJwkPublicKey(jwk)
+}
+
+func (a autoKeyOperations) importJwkPrivateKey(jwk string) (PrivateKey, error) {
+	keyOperations, err := keyOperationsForJwk(jwk, a.rand)
+	if err != nil {
+		return nil, err
+	}
+	return keyOperations.importJwkPrivateKey(jwk)
+}
+
+func (a autoKeyOperations) generateKeyPair() (KeyPair, error) {
+	return NewEcdsaKeyOperationsWithRand(a.rand).generateKeyPair()
+}
+
+// keyOperationsForJwk inspects a JWK's "kty"/"crv" members and returns the
+// KeyOperations implementation able to import it.
+func keyOperationsForJwk(jwk string, rand io.Reader) (KeyOperations, error) {
+	members := struct {
+		Kty string
+		Crv string
+	}{}
+	if err := json.Unmarshal([]byte(jwk), &members); err != nil {
+		return nil, err
+	}
+
+	switch members.Kty {
+	case "EC":
+		return NewEcdsaKeyOperationsWithRand(rand), nil
+	case "RSA":
+		return NewRsaKeyOperationsWithRand(DefaultRsaKeyBits, rand), nil
+	case "OKP":
+		if members.Crv != "Ed25519" {
+			return nil, fmt.Errorf("JWK curve %v is not acceptable", members.Crv)
+		}
+		return NewEd25519KeyOperationsWithRand(rand), nil
+	default:
+		return nil, fmt.Errorf("JWK algorithm %v is not acceptable", members.Kty)
+	}
+}
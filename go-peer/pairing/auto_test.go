@@ -0,0 +1,65 @@
+package pairing
+
+import (
+	"testing"
+)
+
+func TestAutoKeyOperationsDispatchesEcdsa(t *testing.T) {
+	keyPair, err := NewEcdsaKeyOperationsWithRand(onesReader).generateKeyPair()
+	if err != nil {
+		t.Error(err)
+	}
+
+	auto := NewAutoKeyOperations()
+	publicKey, err := auto.importJwkPublicKey(keyPair.PublicKey.exportJwk())
+	if err != nil {
+		t.Error(err)
+	}
+
+	if _, ok := publicKey.(ecdsaPublicKey); !ok {
+		t.Errorf("Expected ecdsaPublicKey, got %T", publicKey)
+	}
+}
+
+func TestAutoKeyOperationsDispatchesRsa(t *testing.T) {
+	keyPair, err := NewRsaKeyOperationsWithBits(2048).generateKeyPair()
+	if err != nil {
+		t.Error(err)
+	}
+
+	auto := NewAutoKeyOperations()
+	publicKey, err := auto.importJwkPublicKey(keyPair.PublicKey.exportJwk())
+	if err != nil {
+		t.Error(err)
+	}
+
+	if _, ok := publicKey.(rsaPublicKey); !ok {
+		t.Errorf("Expected rsaPublicKey, got %T", publicKey)
+	}
+}
+
+func TestAutoKeyOperationsDispatchesEd25519(t *testing.T) {
+	keyPair, err := NewEd25519KeyOperations().generateKeyPair()
+	if err != nil {
+		t.Error(err)
+	}
+
+	auto := NewAutoKeyOperations()
+	publicKey, err := auto.importJwkPublicKey(keyPair.PublicKey.exportJwk())
+	if err != nil {
+		t.Error(err)
+	}
+
+	if _, ok := publicKey.(ed25519PublicKey); !ok {
+		t.Errorf("Expected ed25519PublicKey, got %T", publicKey)
+	}
+}
+
+func TestAutoKeyOperationsRejectsUnknownAlgorithm(t *testing.T) {
+	auto := NewAutoKeyOperations()
+
+	_, err := auto.importJwkPublicKey(`{"kty": "unknown"}`)
+	if err == nil {
+		t.Error("Failed to raise error on unknown algorithm.")
+	}
+}
@@ -0,0 +1,54 @@
+package pairing
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+// concatKDF implements the NIST SP 800-56A Concatenation Key Derivation
+// Function (single-step, SHA-256), as used by JOSE ECDH-ES (RFC 7518 §4.6):
+// derived key material is Hash(counter || Z || AlgorithmID || PartyUInfo ||
+// PartyVInfo || SuppPubInfo), with AlgorithmID/PartyUInfo/PartyVInfo each
+// prefixed with a 4-byte big-endian length, and SuppPubInfo the requested
+// key length in bits, also as 4 bytes.
+func concatKDF(z []byte, algorithmID string, apu, apv []byte, keyDataBits int) []byte {
+	keyDataBytes := keyDataBits / 8
+	otherInfo := concatKDFOtherInfo(algorithmID, apu, apv, keyDataBits)
+
+	reps := (keyDataBytes + sha256.Size - 1) / sha256.Size
+	output := make([]byte, 0, reps*sha256.Size)
+	for i := 1; i <= reps; i++ {
+		h := sha256.New()
+		counter := make([]byte, 4)
+		binary.BigEndian.PutUint32(counter, uint32(i))
+		h.Write(counter)
+		h.Write(z)
+		h.Write(otherInfo)
+		output = append(output, h.Sum(nil)...)
+	}
+
+	return output[:keyDataBytes]
+}
+
+func concatKDFOtherInfo(algorithmID string, apu, apv []byte, keyDataBits int) []byte {
+	var buf bytes.Buffer
+	writeConcatKDFField(&buf, []byte(algorithmID))
+	writeConcatKDFField(&buf, apu)
+	writeConcatKDFField(&buf, apv)
+
+	suppPubInfo := make([]byte, 4)
+	binary.BigEndian.PutUint32(suppPubInfo, uint32(keyDataBits))
+	buf.Write(suppPubInfo)
+
+	return buf.Bytes()
+}
+
+// writeConcatKDFField writes data prefixed with its length, as a 4-byte
+// big-endian integer.
+func writeConcatKDFField(buf *bytes.Buffer, data []byte) {
+	length := make([]byte, 4)
+	binary.BigEndian.PutUint32(length, uint32(len(data)))
+	buf.Write(length)
+	buf.Write(data)
+}
@@ -0,0 +1,150 @@
+package pairing
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+type ed25519KeyOperations struct {
+	rand io.Reader
+}
+
+// Returns a KeyOperations which implements Ed25519 public/private keypairs
+// (JWK kty "OKP", crv "Ed25519").
+func NewEd25519KeyOperations() KeyOperations {
+	return NewEd25519KeyOperationsWithRand(rand.Reader)
+}
+
+func NewEd25519KeyOperationsWithRand(rand io.Reader) KeyOperations {
+	return ed25519KeyOperations{rand}
+}
+
+// Imports a JWK-encoded Ed25519 public key into our PublicKey representation.
+func (ed25519KeyOperations) importJwkPublicKey(jwk string) (key PublicKey, err error) {
+	members := struct {
+		Kty string
+		Crv string
+		X   string
+	}{}
+	err = json.Unmarshal([]byte(jwk), &members)
+	if err != nil {
+		return
+	}
+
+	if members.Kty != "OKP" {
+		err = fmt.Errorf("JWK algorithm %v is not acceptable", members.Kty)
+		return
+	}
+	if members.Crv != "Ed25519" {
+		err = fmt.Errorf("JWK curve %v is not acceptable", members.Crv)
+		return
+	}
+
+	x, err := base64.RawURLEncoding.DecodeString(members.X)
+	if err != nil {
+		return
+	}
+	if len(x) != ed25519.PublicKeySize {
+		err = fmt.Errorf("invalid Ed25519 public key length: %v", len(x))
+		return
+	}
+
+	key = ed25519PublicKey{ed25519.PublicKey(x)}
+	return
+}
+
+func (e ed25519KeyOperations) importJwkPrivateKey(data string) (PrivateKey, error) {
+	members := struct {
+		Kty string
+		Crv string
+		X   string
+		D   string
+	}{}
+	err := json.Unmarshal([]byte(data), &members)
+	if err != nil {
+		return nil, err
+	}
+
+	if members.Kty != "OKP" {
+		return nil, fmt.Errorf("JWK algorithm %v is not acceptable", members.Kty)
+	}
+	if members.Crv != "Ed25519" {
+		return nil, fmt.Errorf("JWK curve %v is not acceptable", members.Crv)
+	}
+
+	seed, err := base64.RawURLEncoding.DecodeString(members.D)
+	if err != nil {
+		return nil, err
+	}
+	if len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("invalid Ed25519 private key seed length: %v", len(seed))
+	}
+
+	return ed25519PrivateKey{ed25519.NewKeyFromSeed(seed)}, nil
+}
+
+// Generates an Ed25519 key pair.
+func (e ed25519KeyOperations) generateKeyPair() (keyPair KeyPair, err error) {
+	publicKey, privateKey, err := ed25519.GenerateKey(e.rand)
+	if err != nil {
+		return
+	}
+
+	keyPair = KeyPair{
+		PublicKey:  ed25519PublicKey{publicKey},
+		PrivateKey: ed25519PrivateKey{privateKey},
+	}
+	return
+}
+
+type ed25519PublicKey struct {
+	publicKey ed25519.PublicKey
+}
+
+type ed25519PrivateKey struct {
+	privateKey ed25519.PrivateKey
+}
+
+func (e ed25519PublicKey) verifyMessage(signature []byte, message string) bool {
+	return ed25519.Verify(e.publicKey, []byte(message), signature)
+}
+
+func (e ed25519PublicKey) exportJwk() string {
+	members := struct {
+		Kty string `json:"kty"`
+		Crv string `json:"crv"`
+		X   string `json:"x"`
+	}{
+		Kty: "OKP",
+		Crv: "Ed25519",
+		X:   base64.RawURLEncoding.EncodeToString(e.publicKey),
+	}
+
+	jwk, _ := json.Marshal(members)
+	return string(jwk)
+}
+
+func (e ed25519PrivateKey) signMessage(message string) ([]byte, error) {
+	return ed25519.Sign(e.privateKey, []byte(message)), nil
+}
+
+func (e ed25519PrivateKey) exportJwk() string {
+	members := struct {
+		Kty string `json:"kty"`
+		Crv string `json:"crv"`
+		X   string `json:"x"`
+		D   string `json:"d"`
+	}{
+		Kty: "OKP",
+		Crv: "Ed25519",
+		X:   base64.RawURLEncoding.EncodeToString(e.privateKey.Public().(ed25519.PublicKey)),
+		D:   base64.RawURLEncoding.EncodeToString(e.privateKey.Seed()),
+	}
+
+	jwk, _ := json.Marshal(members)
+	return string(jwk)
+}
@@ -0,0 +1,68 @@
+package pairing
+
+import (
+	"testing"
+)
+
+func TestEd25519GenerateFullRoundTrip(t *testing.T) {
+	keyOperations := NewEd25519KeyOperations()
+
+	keyPair, err := keyOperations.generateKeyPair()
+	if err != nil {
+		t.Error(err)
+	}
+
+	exportedPublicKey := keyPair.PublicKey.exportJwk()
+	importedPublicKey, err := keyOperations.importJwkPublicKey(exportedPublicKey)
+	if err != nil {
+		t.Error(err)
+	}
+
+	exportedPrivateKey := keyPair.PrivateKey.exportJwk()
+	importedPrivateKey, err := keyOperations.importJwkPrivateKey(exportedPrivateKey)
+	if err != nil {
+		t.Error(err)
+	}
+
+	signature, err := importedPrivateKey.signMessage("hello")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !importedPublicKey.verifyMessage(signature, "hello") {
+		t.Error("Failed to verify own signature")
+	}
+}
+
+func TestEd25519PublicKeyImportInvalidCurve(t *testing.T) {
+	keyOperations := NewEd25519KeyOperations()
+
+	_, err := keyOperations.importJwkPublicKey(`
+	{
+		"kty": "OKP",
+		"crv": "X25519",
+		"x": "MKBCTNIcKUSDii11ySs3526iDZ8AiTo7Tu6KPAqv7D4"
+	}
+	`)
+	if err == nil {
+		t.Error("Failed to raise error on invalid curve.")
+	}
+}
+
+func TestEd25519VerifyRejectsTamperedMessage(t *testing.T) {
+	keyOperations := NewEd25519KeyOperations()
+
+	keyPair, err := keyOperations.generateKeyPair()
+	if err != nil {
+		t.Error(err)
+	}
+
+	signature, err := keyPair.PrivateKey.signMessage("hello")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if keyPair.PublicKey.verifyMessage(signature, "goodbye") {
+		t.Error("Verified incorrect message")
+	}
+}
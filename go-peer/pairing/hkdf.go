@@ -0,0 +1,31 @@
+package pairing
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+)
+
+// hkdfSha256 derives length bytes of key material from secret, following
+// RFC 5869 HKDF using SHA-256 as the underlying hash.
+func hkdfSha256(secret, salt, info []byte, length int) []byte {
+	prk := hmacSha256(salt, secret)
+
+	var block []byte
+	okm := make([]byte, 0, length)
+	for counter := byte(1); len(okm) < length; counter++ {
+		mac := hmac.New(sha256.New, prk)
+		mac.Write(block)
+		mac.Write(info)
+		mac.Write([]byte{counter})
+		block = mac.Sum(nil)
+		okm = append(okm, block...)
+	}
+
+	return okm[:length]
+}
+
+func hmacSha256(key, data []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,226 @@
+package pairing
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+const shortCodeInfo = "thing-rtc-go pairing short code"
+
+// Invite is a compact out-of-band pairing payload, suitable for encoding as
+// a QR code, that bootstraps a PairingTokenGenerator on the accepting side.
+type Invite struct {
+	Blob string
+}
+
+// invitePayload is the data signed (or encrypted) inside an Invite.
+type invitePayload struct {
+	PairingId    string          `json:"pairingId"`
+	Role         string          `json:"role"`
+	PublicKeyJwk json.RawMessage `json:"publicKeyJwk"`
+	Nonce        string          `json:"nonce"`
+	ExpiresAt    time.Time       `json:"expiresAt"`
+}
+
+// NewPairingInvite creates an Invite for local, valid for ttl, as a
+// URL-safe base64 blob of the form base64url(payload) + "." +
+// base64url(signature), self-signed with local's private key so the
+// accepting side can trust the embedded public key without it being known
+// in advance.
+func NewPairingInvite(local KeyPair, ttl time.Duration) (invite Invite, err error) {
+	payloadBytes, err := json.Marshal(invitePayload{
+		PairingId:    GenerateNonce(),
+		Role:         "initiator",
+		PublicKeyJwk: json.RawMessage(local.PublicKey.exportJwk()),
+		Nonce:        GenerateNonce(),
+		ExpiresAt:    time.Now().Add(ttl),
+	})
+	if err != nil {
+		return
+	}
+
+	signature, err := local.PrivateKey.signMessage(string(payloadBytes))
+	if err != nil {
+		return
+	}
+
+	invite = Invite{
+		Blob: base64.RawURLEncoding.EncodeToString(payloadBytes) + "." + base64.RawURLEncoding.EncodeToString(signature),
+	}
+	return
+}
+
+// AcceptPairingInvite verifies and decodes an Invite blob produced by
+// NewPairingInvite, checks that it has not expired, and returns a
+// PairingTokenGenerator with the peer's public key installed.
+func AcceptPairingInvite(blob string, local KeyPair) (generator PairingTokenGenerator, err error) {
+	parts := strings.SplitN(blob, ".", 2)
+	if len(parts) != 2 {
+		err = fmt.Errorf("pairing invite must have 2 segments, found %v", len(parts))
+		return
+	}
+
+	payloadBytes, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return
+	}
+
+	var payload invitePayload
+	if err = json.Unmarshal(payloadBytes, &payload); err != nil {
+		return
+	}
+
+	if time.Now().After(payload.ExpiresAt) {
+		err = fmt.Errorf("pairing invite expired at %v", payload.ExpiresAt)
+		return
+	}
+
+	remotePublicKey, err := NewAutoKeyOperations().importJwkPublicKey(string(payload.PublicKeyJwk))
+	if err != nil {
+		return
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return
+	}
+	if !remotePublicKey.verifyMessage(signature, string(payloadBytes)) {
+		err = fmt.Errorf("pairing invite signature verification failed")
+		return
+	}
+
+	generator = PairingTokenGenerator{pairingData{
+		pairingId:       payload.PairingId,
+		role:            "responder",
+		remotePublicKey: remotePublicKey,
+		localKeyPair:    local,
+	}}
+	return
+}
+
+// NewPairingInviteWithCode is like NewPairingInvite, but instead of
+// self-signing the payload, wraps it with AES-GCM under a key HKDF-derived
+// from a freshly generated numeric short code, suitable for a user to read
+// aloud or type in rather than scanning a QR code. The code must be passed
+// to AcceptPairingInviteWithCode out of band.
+func NewPairingInviteWithCode(local KeyPair, ttl time.Duration, codeDigits int) (invite Invite, code string, err error) {
+	code, err = generateNumericCode(codeDigits)
+	if err != nil {
+		return
+	}
+
+	payloadBytes, err := json.Marshal(invitePayload{
+		PairingId:    GenerateNonce(),
+		Role:         "initiator",
+		PublicKeyJwk: json.RawMessage(local.PublicKey.exportJwk()),
+		Nonce:        GenerateNonce(),
+		ExpiresAt:    time.Now().Add(ttl),
+	})
+	if err != nil {
+		return
+	}
+
+	salt := make([]byte, 16)
+	if _, err = rand.Read(salt); err != nil {
+		return
+	}
+
+	gcm, err := newGcm(hkdfSha256([]byte(code), salt, []byte(shortCodeInfo), 32))
+	if err != nil {
+		return
+	}
+
+	iv := make([]byte, gcm.NonceSize())
+	if _, err = rand.Read(iv); err != nil {
+		return
+	}
+
+	sealed := gcm.Seal(nil, iv, payloadBytes, nil)
+
+	invite = Invite{
+		Blob: strings.Join([]string{
+			base64.RawURLEncoding.EncodeToString(salt),
+			base64.RawURLEncoding.EncodeToString(iv),
+			base64.RawURLEncoding.EncodeToString(sealed),
+		}, "."),
+	}
+	return
+}
+
+// AcceptPairingInviteWithCode reverses NewPairingInviteWithCode: it derives
+// the same key from code, decrypts the Invite blob, checks expiry, and
+// returns a PairingTokenGenerator with the peer's public key installed.
+func AcceptPairingInviteWithCode(blob string, code string, local KeyPair) (generator PairingTokenGenerator, err error) {
+	parts := strings.Split(blob, ".")
+	if len(parts) != 3 {
+		err = fmt.Errorf("pairing invite must have 3 segments, found %v", len(parts))
+		return
+	}
+
+	salt, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return
+	}
+	iv, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return
+	}
+	sealed, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return
+	}
+
+	gcm, err := newGcm(hkdfSha256([]byte(code), salt, []byte(shortCodeInfo), 32))
+	if err != nil {
+		return
+	}
+
+	payloadBytes, err := gcm.Open(nil, iv, sealed, nil)
+	if err != nil {
+		return
+	}
+
+	var payload invitePayload
+	if err = json.Unmarshal(payloadBytes, &payload); err != nil {
+		return
+	}
+
+	if time.Now().After(payload.ExpiresAt) {
+		err = fmt.Errorf("pairing invite expired at %v", payload.ExpiresAt)
+		return
+	}
+
+	remotePublicKey, err := NewAutoKeyOperations().importJwkPublicKey(string(payload.PublicKeyJwk))
+	if err != nil {
+		return
+	}
+
+	generator = PairingTokenGenerator{pairingData{
+		pairingId:       payload.PairingId,
+		role:            "responder",
+		remotePublicKey: remotePublicKey,
+		localKeyPair:    local,
+	}}
+	return
+}
+
+// generateNumericCode returns a cryptographically random numeric string of
+// the given number of digits (6-8 is the recommended range).
+func generateNumericCode(digits int) (string, error) {
+	max := int64(1)
+	for i := 0; i < digits; i++ {
+		max *= 10
+	}
+
+	n, err := rand.Int(rand.Reader, big.NewInt(max))
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%0*d", digits, n.Int64()), nil
+}
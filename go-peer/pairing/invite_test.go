@@ -0,0 +1,139 @@
+package pairing
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPairingInviteRoundTrip(t *testing.T) {
+	keyOperations := NewEcdsaKeyOperations()
+
+	initiatorKeyPair, err := keyOperations.generateKeyPair()
+	if err != nil {
+		t.Error(err)
+	}
+	responderKeyPair, err := keyOperations.generateKeyPair()
+	if err != nil {
+		t.Error(err)
+	}
+
+	invite, err := NewPairingInvite(initiatorKeyPair, time.Hour)
+	if err != nil {
+		t.Error(err)
+	}
+
+	generator, err := AcceptPairingInvite(invite.Blob, responderKeyPair)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if generator.GetRole() != "responder" {
+		t.Errorf("Incorrect role: %v", generator.GetRole())
+	}
+
+	// The responder's peer public key should be the initiator's, so a
+	// signature from the initiator should verify against it.
+	initiatorSignature, err := initiatorKeyPair.PrivateKey.signMessage("hello")
+	if err != nil {
+		t.Error(err)
+	}
+	if !generator.remotePublicKey.verifyMessage(initiatorSignature, "hello") {
+		t.Error("Expected invite's embedded public key to match the initiator's")
+	}
+}
+
+func TestPairingInviteRejectsExpired(t *testing.T) {
+	keyOperations := NewEcdsaKeyOperations()
+
+	initiatorKeyPair, err := keyOperations.generateKeyPair()
+	if err != nil {
+		t.Error(err)
+	}
+	responderKeyPair, err := keyOperations.generateKeyPair()
+	if err != nil {
+		t.Error(err)
+	}
+
+	invite, err := NewPairingInvite(initiatorKeyPair, -time.Hour)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if _, err := AcceptPairingInvite(invite.Blob, responderKeyPair); err == nil {
+		t.Error("Expected expired invite to be rejected")
+	}
+}
+
+func TestPairingInviteRejectsTamperedPayload(t *testing.T) {
+	keyOperations := NewEcdsaKeyOperations()
+
+	initiatorKeyPair, err := keyOperations.generateKeyPair()
+	if err != nil {
+		t.Error(err)
+	}
+	responderKeyPair, err := keyOperations.generateKeyPair()
+	if err != nil {
+		t.Error(err)
+	}
+
+	invite, err := NewPairingInvite(initiatorKeyPair, time.Hour)
+	if err != nil {
+		t.Error(err)
+	}
+
+	tampered := invite.Blob + "AA"
+	if _, err := AcceptPairingInvite(tampered, responderKeyPair); err == nil {
+		t.Error("Expected tampered invite to be rejected")
+	}
+}
+
+func TestPairingInviteWithCodeRoundTrip(t *testing.T) {
+	keyOperations := NewEcdsaKeyOperations()
+
+	initiatorKeyPair, err := keyOperations.generateKeyPair()
+	if err != nil {
+		t.Error(err)
+	}
+	responderKeyPair, err := keyOperations.generateKeyPair()
+	if err != nil {
+		t.Error(err)
+	}
+
+	invite, code, err := NewPairingInviteWithCode(initiatorKeyPair, time.Hour, 6)
+	if err != nil {
+		t.Error(err)
+	}
+	if len(code) != 6 {
+		t.Errorf("Expected a 6-digit code, got %v", code)
+	}
+
+	generator, err := AcceptPairingInviteWithCode(invite.Blob, code, responderKeyPair)
+	if err != nil {
+		t.Error(err)
+	}
+	if generator.GetRole() != "responder" {
+		t.Errorf("Incorrect role: %v", generator.GetRole())
+	}
+}
+
+func TestPairingInviteWithCodeRejectsWrongCode(t *testing.T) {
+	keyOperations := NewEcdsaKeyOperations()
+
+	initiatorKeyPair, err := keyOperations.generateKeyPair()
+	if err != nil {
+		t.Error(err)
+	}
+	responderKeyPair, err := keyOperations.generateKeyPair()
+	if err != nil {
+		t.Error(err)
+	}
+
+	invite, _, err := NewPairingInviteWithCode(initiatorKeyPair, time.Hour, 6)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if _, err := AcceptPairingInviteWithCode(invite.Blob, "000000", responderKeyPair); err == nil {
+		t.Error("Expected wrong code to be rejected")
+	}
+}
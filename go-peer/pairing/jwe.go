@@ -0,0 +1,174 @@
+package pairing
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// jweHeader is the protected header of a compact-serialized JWE, as produced
+// and consumed by EncryptForPeer/DecryptFromPeer.
+type jweHeader struct {
+	Alg string          `json:"alg"`
+	Enc string          `json:"enc"`
+	Epk json.RawMessage `json:"epk"`
+}
+
+// EncryptForPeer encrypts plaintext for the remote peer and returns it as a
+// JOSE compact-serialized JWE, using ECDH-ES+A256KW key agreement over an
+// ephemeral P-256 key pair and A256GCM content encryption.
+func (p PairingTokenGenerator) EncryptForPeer(plaintext []byte) (string, error) {
+	remoteKey, ok := p.remotePublicKey.(ecdsaPublicKey)
+	if !ok {
+		return "", fmt.Errorf("JWE encryption is only supported for ECDSA P-256 keys")
+	}
+
+	ephemeralPrivate, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return "", err
+	}
+
+	z, err := ecdhSharedSecret(ephemeralPrivate, remoteKey.publicKey)
+	if err != nil {
+		return "", err
+	}
+
+	epkJwk := ecdsaPublicKey{&ephemeralPrivate.PublicKey}.exportJwk()
+	headerBytes, err := json.Marshal(jweHeader{Alg: "ECDH-ES+A256KW", Enc: "A256GCM", Epk: json.RawMessage(epkJwk)})
+	if err != nil {
+		return "", err
+	}
+	protected := base64.RawURLEncoding.EncodeToString(headerBytes)
+
+	kek := concatKDF(z, "A256KW", nil, nil, 256)
+
+	cek := make([]byte, 32)
+	if _, err := rand.Read(cek); err != nil {
+		return "", err
+	}
+	encryptedKey, err := aesKeyWrap(kek, cek)
+	if err != nil {
+		return "", err
+	}
+
+	gcm, err := newGcm(cek)
+	if err != nil {
+		return "", err
+	}
+
+	iv := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(iv); err != nil {
+		return "", err
+	}
+
+	sealed := gcm.Seal(nil, iv, plaintext, []byte(protected))
+	ciphertext := sealed[:len(sealed)-gcm.Overhead()]
+	tag := sealed[len(sealed)-gcm.Overhead():]
+
+	return strings.Join([]string{
+		protected,
+		base64.RawURLEncoding.EncodeToString(encryptedKey),
+		base64.RawURLEncoding.EncodeToString(iv),
+		base64.RawURLEncoding.EncodeToString(ciphertext),
+		base64.RawURLEncoding.EncodeToString(tag),
+	}, "."), nil
+}
+
+// DecryptFromPeer decrypts a JOSE compact-serialized JWE produced by the
+// peer's EncryptForPeer, using the local ECDSA P-256 private key.
+func (p PairingTokenGenerator) DecryptFromPeer(jwe string) ([]byte, error) {
+	localKey, ok := p.localKeyPair.PrivateKey.(ecdsaPrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("JWE decryption is only supported for ECDSA P-256 keys")
+	}
+
+	parts := strings.Split(jwe, ".")
+	if len(parts) != 5 {
+		return nil, fmt.Errorf("JWE token must have 5 segments, found %v", len(parts))
+	}
+
+	headerBytes, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, err
+	}
+	var header jweHeader
+	if err := json.Unmarshal(headerBytes, &header); err != nil {
+		return nil, err
+	}
+	if header.Alg != "ECDH-ES+A256KW" {
+		return nil, fmt.Errorf("unsupported JWE algorithm: %v", header.Alg)
+	}
+	if header.Enc != "A256GCM" {
+		return nil, fmt.Errorf("unsupported JWE encryption: %v", header.Enc)
+	}
+
+	epkPublic, err := NewEcdsaKeyOperations().importJwkPublicKey(string(header.Epk))
+	if err != nil {
+		return nil, err
+	}
+	epkEcdsa, ok := epkPublic.(ecdsaPublicKey)
+	if !ok {
+		return nil, fmt.Errorf("epk is not an ECDSA public key")
+	}
+
+	z, err := ecdhSharedSecret(localKey.privateKey, epkEcdsa.publicKey)
+	if err != nil {
+		return nil, err
+	}
+	kek := concatKDF(z, "A256KW", nil, nil, 256)
+
+	encryptedKey, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, err
+	}
+	cek, err := aesKeyUnwrap(kek, encryptedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	iv, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, err := base64.RawURLEncoding.DecodeString(parts[3])
+	if err != nil {
+		return nil, err
+	}
+	tag, err := base64.RawURLEncoding.DecodeString(parts[4])
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := newGcm(cek)
+	if err != nil {
+		return nil, err
+	}
+
+	sealed := append(append([]byte{}, ciphertext...), tag...)
+	return gcm.Open(nil, iv, sealed, []byte(parts[0]))
+}
+
+func newGcm(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// ecdhSharedSecret performs ECDH between private and public, returning the
+// shared secret's X coordinate as a fixed-width 32-byte big-endian value.
+func ecdhSharedSecret(private *ecdsa.PrivateKey, public *ecdsa.PublicKey) ([]byte, error) {
+	if !private.Curve.IsOnCurve(public.X, public.Y) {
+		return nil, fmt.Errorf("peer public key is not on the expected curve")
+	}
+
+	x, _ := private.Curve.ScalarMult(public.X, public.Y, private.D.Bytes())
+	return padBytes(x.Bytes(), 32), nil
+}
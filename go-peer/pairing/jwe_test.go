@@ -0,0 +1,125 @@
+package pairing
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestJweRoundTrip(t *testing.T) {
+	keyOperations := NewEcdsaKeyOperations()
+
+	aliceKeyPair, err := keyOperations.generateKeyPair()
+	if err != nil {
+		t.Error(err)
+	}
+	bobKeyPair, err := keyOperations.generateKeyPair()
+	if err != nil {
+		t.Error(err)
+	}
+
+	alice := PairingTokenGenerator{pairingData{
+		remotePublicKey: bobKeyPair.PublicKey,
+		localKeyPair:    aliceKeyPair,
+	}}
+	bob := PairingTokenGenerator{pairingData{
+		remotePublicKey: aliceKeyPair.PublicKey,
+		localKeyPair:    bobKeyPair,
+	}}
+
+	jwe, err := alice.EncryptForPeer([]byte("secret token"))
+	if err != nil {
+		t.Error(err)
+	}
+
+	plaintext, err := bob.DecryptFromPeer(jwe)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if string(plaintext) != "secret token" {
+		t.Errorf("Incorrect plaintext: %v", string(plaintext))
+	}
+}
+
+func TestJweHasFiveSegments(t *testing.T) {
+	keyOperations := NewEcdsaKeyOperations()
+	keyPair, err := keyOperations.generateKeyPair()
+	if err != nil {
+		t.Error(err)
+	}
+
+	p := PairingTokenGenerator{pairingData{
+		remotePublicKey: keyPair.PublicKey,
+		localKeyPair:    keyPair,
+	}}
+
+	jwe, err := p.EncryptForPeer([]byte("hello"))
+	if err != nil {
+		t.Error(err)
+	}
+
+	if segments := strings.Split(jwe, "."); len(segments) != 5 {
+		t.Errorf("Expected 5 segments, got %v", len(segments))
+	}
+}
+
+func TestJweDecryptRejectsTamperedCiphertext(t *testing.T) {
+	keyOperations := NewEcdsaKeyOperations()
+	keyPair, err := keyOperations.generateKeyPair()
+	if err != nil {
+		t.Error(err)
+	}
+
+	p := PairingTokenGenerator{pairingData{
+		remotePublicKey: keyPair.PublicKey,
+		localKeyPair:    keyPair,
+	}}
+
+	jwe, err := p.EncryptForPeer([]byte("hello"))
+	if err != nil {
+		t.Error(err)
+	}
+
+	segments := strings.Split(jwe, ".")
+	segments[3] = segments[3] + "AA"
+	tampered := strings.Join(segments, ".")
+
+	if _, err := p.DecryptFromPeer(tampered); err == nil {
+		t.Error("Expected decryption of tampered JWE to fail")
+	}
+}
+
+func TestAesKeyWrapRoundTrip(t *testing.T) {
+	kek := bytes.Repeat([]byte{1}, 32)
+	cek := bytes.Repeat([]byte{2}, 32)
+
+	wrapped, err := aesKeyWrap(kek, cek)
+	if err != nil {
+		t.Error(err)
+	}
+
+	unwrapped, err := aesKeyUnwrap(kek, wrapped)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !bytes.Equal(unwrapped, cek) {
+		t.Errorf("Unwrapped key %v does not match original %v", unwrapped, cek)
+	}
+}
+
+func TestAesKeyUnwrapRejectsCorruptedInput(t *testing.T) {
+	kek := bytes.Repeat([]byte{1}, 32)
+	cek := bytes.Repeat([]byte{2}, 32)
+
+	wrapped, err := aesKeyWrap(kek, cek)
+	if err != nil {
+		t.Error(err)
+	}
+	wrapped[0] ^= 0xFF
+
+	if _, err := aesKeyUnwrap(kek, wrapped); err == nil {
+		t.Error("Expected unwrap of corrupted input to fail")
+	}
+}
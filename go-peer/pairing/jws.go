@@ -0,0 +1,117 @@
+package pairing
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// jwsHeader is the protected header of a compact-serialized JWS, as produced
+// and consumed by SignJws/VerifyJws.
+type jwsHeader struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ"`
+	Kid string `json:"kid"`
+}
+
+// SignJws signs payload and returns it as a JOSE compact-serialized JWS
+// (base64url(header) + "." + base64url(payload) + "." + base64url(signature)),
+// using ES256 over the existing IEEE P1363 ECDSA signature.
+func (p PairingTokenGenerator) SignJws(payload []byte) (string, error) {
+	kid, err := keyFingerprint(p.localKeyPair.PublicKey)
+	if err != nil {
+		return "", err
+	}
+
+	headerBytes, err := json.Marshal(jwsHeader{Alg: "ES256", Typ: "JWT", Kid: kid})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(headerBytes) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	signature, err := p.localKeyPair.PrivateKey.signMessage(signingInput)
+	if err != nil {
+		return "", err
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// VerifyJws parses a JOSE compact-serialized JWS, checks its kid against the
+// remote peer's public key, verifies the ES256 signature, and returns the
+// decoded payload.
+func (p PairingTokenGenerator) VerifyJws(token string) ([]byte, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("JWS token must have 3 segments, found %v", len(parts))
+	}
+
+	headerBytes, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var header jwsHeader
+	if err := json.Unmarshal(headerBytes, &header); err != nil {
+		return nil, err
+	}
+	if header.Alg != "ES256" {
+		return nil, fmt.Errorf("unsupported JWS algorithm: %v", header.Alg)
+	}
+
+	expectedKid, err := keyFingerprint(p.remotePublicKey)
+	if err != nil {
+		return nil, err
+	}
+	if header.Kid != expectedKid {
+		return nil, fmt.Errorf("JWS kid %v does not match expected peer kid %v", header.Kid, expectedKid)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, err
+	}
+
+	signingInput := parts[0] + "." + parts[1]
+	if !p.remotePublicKey.verifyMessage(signature, signingInput) {
+		return nil, fmt.Errorf("JWS signature verification failed")
+	}
+
+	return base64.RawURLEncoding.DecodeString(parts[1])
+}
+
+// keyFingerprint computes the libtrust-style kid for key: the SHA-256 digest
+// of its DER-encoded SubjectPublicKeyInfo, truncated to 240 bits,
+// base32-encoded, and split into 12 colon-separated groups of 4 characters
+// (e.g. "PYYO:TEWU:...").
+func keyFingerprint(key PublicKey) (string, error) {
+	der, err := publicKeyDer(key)
+	if err != nil {
+		return "", err
+	}
+
+	digest := sha256.Sum256(der)
+	encoded := base32.StdEncoding.EncodeToString(digest[:30])
+
+	groups := make([]string, 0, len(encoded)/4)
+	for i := 0; i < len(encoded); i += 4 {
+		groups = append(groups, encoded[i:i+4])
+	}
+
+	return strings.Join(groups, ":"), nil
+}
+
+// publicKeyDer returns the DER-encoded SubjectPublicKeyInfo for key.
+func publicKeyDer(key PublicKey) ([]byte, error) {
+	ecdsaKey, ok := key.(ecdsaPublicKey)
+	if !ok {
+		return nil, fmt.Errorf("key fingerprinting is only supported for ECDSA keys")
+	}
+
+	return x509.MarshalPKIXPublicKey(ecdsaKey.publicKey)
+}
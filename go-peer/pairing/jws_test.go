@@ -0,0 +1,85 @@
+package pairing
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestJwsRoundTrip(t *testing.T) {
+	p, err := createPairingTokenGenerator()
+	if err != nil {
+		t.Error(err)
+	}
+
+	token, err := p.SignJws([]byte("hello"))
+	if err != nil {
+		t.Error(err)
+	}
+
+	payload, err := p.VerifyJws(token)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if string(payload) != "hello" {
+		t.Errorf("Incorrect payload: %v", string(payload))
+	}
+}
+
+func TestJwsHasThreeSegments(t *testing.T) {
+	p, err := createPairingTokenGenerator()
+	if err != nil {
+		t.Error(err)
+	}
+
+	token, err := p.SignJws([]byte("hello"))
+	if err != nil {
+		t.Error(err)
+	}
+
+	if segments := strings.Split(token, "."); len(segments) != 3 {
+		t.Errorf("Expected 3 segments, got %v", len(segments))
+	}
+}
+
+func TestJwsVerifyRejectsTamperedPayload(t *testing.T) {
+	p, err := createPairingTokenGenerator()
+	if err != nil {
+		t.Error(err)
+	}
+
+	token, err := p.SignJws([]byte("hello"))
+	if err != nil {
+		t.Error(err)
+	}
+
+	segments := strings.Split(token, ".")
+	segments[1] = "dGFtcGVyZWQ" // base64url("tampered")
+	tampered := strings.Join(segments, ".")
+
+	if _, err := p.VerifyJws(tampered); err == nil {
+		t.Error("Expected verification of tampered JWS to fail")
+	}
+}
+
+func TestKeyFingerprintFormat(t *testing.T) {
+	p, err := createPairingTokenGenerator()
+	if err != nil {
+		t.Error(err)
+	}
+
+	kid, err := keyFingerprint(p.localKeyPair.PublicKey)
+	if err != nil {
+		t.Error(err)
+	}
+
+	groups := strings.Split(kid, ":")
+	if len(groups) != 12 {
+		t.Errorf("Expected 12 groups, got %v", len(groups))
+	}
+	for _, group := range groups {
+		if len(group) != 4 {
+			t.Errorf("Expected group of length 4, got %v", group)
+		}
+	}
+}
@@ -0,0 +1,261 @@
+package pairing
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// KeyStoreEntry describes one trusted peer public key held by a KeyStore,
+// along with the metadata persisted alongside it.
+type KeyStoreEntry struct {
+	Kid     string
+	Key     PublicKey
+	Use     string
+	Alg     string
+	Label   string
+	AddedAt time.Time
+}
+
+// KeyStore persists a set of trusted peer public keys, and optionally a
+// local key pair, to disk as a JWK Set (RFC 7517).
+type KeyStore struct {
+	path  string
+	peers map[string]KeyStoreEntry
+	local *localKeyEntry
+}
+
+type localKeyEntry struct {
+	Kid     string
+	KeyPair KeyPair
+	Label   string
+	AddedAt time.Time
+}
+
+// jwkSetFile is the on-disk representation of a KeyStore: a plain JWK Set
+// with our metadata (kid, use, alg, label, addedAt) riding alongside the
+// standard JWK members of each key.
+type jwkSetFile struct {
+	Keys []json.RawMessage `json:"keys"`
+}
+
+type keyMetadata struct {
+	Kid     string    `json:"kid"`
+	Use     string    `json:"use"`
+	Alg     string    `json:"alg"`
+	Label   string    `json:"label,omitempty"`
+	AddedAt time.Time `json:"addedAt"`
+}
+
+// LoadKeyStore reads a KeyStore from path, as previously written by Save. If
+// path does not exist, an empty KeyStore backed by that path is returned.
+func LoadKeyStore(path string) (*KeyStore, error) {
+	store := &KeyStore{path: path, peers: map[string]KeyStoreEntry{}}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var file jwkSetFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+
+	keyOperations := NewAutoKeyOperations()
+	for _, rawKey := range file.Keys {
+		var meta keyMetadata
+		if err := json.Unmarshal(rawKey, &meta); err != nil {
+			return nil, err
+		}
+
+		if meta.Use == "local" {
+			privateKey, err := keyOperations.importJwkPrivateKey(string(rawKey))
+			if err != nil {
+				return nil, err
+			}
+			publicKey, err := keyOperations.importJwkPublicKey(string(rawKey))
+			if err != nil {
+				return nil, err
+			}
+			store.local = &localKeyEntry{
+				Kid:     meta.Kid,
+				KeyPair: KeyPair{PublicKey: publicKey, PrivateKey: privateKey},
+				Label:   meta.Label,
+				AddedAt: meta.AddedAt,
+			}
+			continue
+		}
+
+		publicKey, err := keyOperations.importJwkPublicKey(string(rawKey))
+		if err != nil {
+			return nil, err
+		}
+		store.peers[meta.Kid] = KeyStoreEntry{
+			Kid:     meta.Kid,
+			Key:     publicKey,
+			Use:     meta.Use,
+			Alg:     meta.Alg,
+			Label:   meta.Label,
+			AddedAt: meta.AddedAt,
+		}
+	}
+
+	return store, nil
+}
+
+// Add records a trusted peer public key under kid, for later Lookup.
+func (s *KeyStore) Add(kid string, key PublicKey) {
+	s.AddWithLabel(kid, key, "")
+}
+
+// AddWithLabel is like Add, but also attaches a user-facing label to the
+// entry (e.g. a device or contact name), surfaced via List.
+func (s *KeyStore) AddWithLabel(kid string, key PublicKey, label string) {
+	s.peers[kid] = KeyStoreEntry{
+		Kid:     kid,
+		Key:     key,
+		Use:     "sig",
+		Alg:     keyAlg(key),
+		Label:   label,
+		AddedAt: time.Now(),
+	}
+}
+
+// Lookup returns the trusted peer public key registered under kid, if any.
+func (s *KeyStore) Lookup(kid string) (PublicKey, bool) {
+	entry, ok := s.peers[kid]
+	if !ok {
+		return nil, false
+	}
+	return entry.Key, true
+}
+
+// Remove revokes the trusted peer public key registered under kid.
+func (s *KeyStore) Remove(kid string) {
+	delete(s.peers, kid)
+}
+
+// List returns every trusted peer entry currently in the store.
+func (s *KeyStore) List() []KeyStoreEntry {
+	entries := make([]KeyStoreEntry, 0, len(s.peers))
+	for _, entry := range s.peers {
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// SetLocalKeyPair records the local key pair under kid, to be persisted
+// alongside the trusted peer keys.
+func (s *KeyStore) SetLocalKeyPair(kid string, keyPair KeyPair, label string) {
+	s.local = &localKeyEntry{
+		Kid:     kid,
+		KeyPair: keyPair,
+		Label:   label,
+		AddedAt: time.Now(),
+	}
+}
+
+// LocalKeyPair returns the local key pair previously set via
+// SetLocalKeyPair, if any.
+func (s *KeyStore) LocalKeyPair() (KeyPair, bool) {
+	if s.local == nil {
+		return KeyPair{}, false
+	}
+	return s.local.KeyPair, true
+}
+
+// Save atomically writes the KeyStore to its backing path as a JWK Set,
+// via a temp file in the same directory followed by a rename.
+func (s *KeyStore) Save() error {
+	file := jwkSetFile{Keys: make([]json.RawMessage, 0, len(s.peers)+1)}
+
+	if s.local != nil {
+		raw, err := mergeKeyMetadata(s.local.KeyPair.PrivateKey.exportJwk(), keyMetadata{
+			Kid:     s.local.Kid,
+			Use:     "local",
+			Alg:     keyAlg(s.local.KeyPair.PublicKey),
+			Label:   s.local.Label,
+			AddedAt: s.local.AddedAt,
+		})
+		if err != nil {
+			return err
+		}
+		file.Keys = append(file.Keys, raw)
+	}
+
+	for _, entry := range s.peers {
+		raw, err := mergeKeyMetadata(entry.Key.exportJwk(), keyMetadata{
+			Kid:     entry.Kid,
+			Use:     entry.Use,
+			Alg:     entry.Alg,
+			Label:   entry.Label,
+			AddedAt: entry.AddedAt,
+		})
+		if err != nil {
+			return err
+		}
+		file.Keys = append(file.Keys, raw)
+	}
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(s.path)
+	tempFile, err := os.CreateTemp(dir, ".keystore-*.tmp")
+	if err != nil {
+		return err
+	}
+	tempPath := tempFile.Name()
+	defer os.Remove(tempPath)
+
+	if _, err := tempFile.Write(data); err != nil {
+		tempFile.Close()
+		return err
+	}
+	if err := tempFile.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tempPath, s.path)
+}
+
+// mergeKeyMetadata combines an exported JWK with the metadata members we
+// persist alongside it (kid, use, alg, label, addedAt).
+func mergeKeyMetadata(jwk string, meta keyMetadata) (json.RawMessage, error) {
+	var members map[string]interface{}
+	if err := json.Unmarshal([]byte(jwk), &members); err != nil {
+		return nil, err
+	}
+
+	members["kid"] = meta.Kid
+	members["use"] = meta.Use
+	members["alg"] = meta.Alg
+	if meta.Label != "" {
+		members["label"] = meta.Label
+	}
+	members["addedAt"] = meta.AddedAt
+
+	return json.Marshal(members)
+}
+
+// keyAlg returns the JWA algorithm identifier for key's type.
+func keyAlg(key PublicKey) string {
+	switch key.(type) {
+	case ecdsaPublicKey:
+		return "ES256"
+	case rsaPublicKey:
+		return "RS256"
+	case ed25519PublicKey:
+		return "EdDSA"
+	default:
+		return fmt.Sprintf("%T", key)
+	}
+}
@@ -0,0 +1,93 @@
+package pairing
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestKeyStoreAddLookupRemove(t *testing.T) {
+	keyPair, err := NewEcdsaKeyOperationsWithRand(onesReader).generateKeyPair()
+	if err != nil {
+		t.Error(err)
+	}
+
+	store, err := LoadKeyStore(filepath.Join(t.TempDir(), "keystore.json"))
+	if err != nil {
+		t.Error(err)
+	}
+
+	store.Add("peer1", keyPair.PublicKey)
+
+	key, ok := store.Lookup("peer1")
+	if !ok {
+		t.Error("Expected to find peer1")
+	}
+	if key != keyPair.PublicKey {
+		t.Error("Looked-up key did not match added key")
+	}
+
+	store.Remove("peer1")
+	if _, ok := store.Lookup("peer1"); ok {
+		t.Error("Expected peer1 to be removed")
+	}
+}
+
+func TestKeyStoreSaveLoadRoundTrip(t *testing.T) {
+	peerKeyPair, err := NewEcdsaKeyOperationsWithRand(onesReader).generateKeyPair()
+	if err != nil {
+		t.Error(err)
+	}
+	localKeyPair, err := NewEd25519KeyOperations().generateKeyPair()
+	if err != nil {
+		t.Error(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "keystore.json")
+
+	store, err := LoadKeyStore(path)
+	if err != nil {
+		t.Error(err)
+	}
+	store.AddWithLabel("peer1", peerKeyPair.PublicKey, "my phone")
+	store.SetLocalKeyPair("local1", localKeyPair, "this device")
+
+	if err := store.Save(); err != nil {
+		t.Error(err)
+	}
+
+	reloaded, err := LoadKeyStore(path)
+	if err != nil {
+		t.Error(err)
+	}
+
+	key, ok := reloaded.Lookup("peer1")
+	if !ok {
+		t.Error("Expected to find peer1 after reload")
+	}
+	if key.exportJwk() != peerKeyPair.PublicKey.exportJwk() {
+		t.Error("Reloaded peer key did not match original")
+	}
+
+	entries := reloaded.List()
+	if len(entries) != 1 || entries[0].Label != "my phone" {
+		t.Errorf("Unexpected entries after reload: %+v", entries)
+	}
+
+	local, ok := reloaded.LocalKeyPair()
+	if !ok {
+		t.Error("Expected local key pair after reload")
+	}
+	if local.PrivateKey.exportJwk() != localKeyPair.PrivateKey.exportJwk() {
+		t.Error("Reloaded local key pair did not match original")
+	}
+}
+
+func TestLoadKeyStoreMissingFile(t *testing.T) {
+	store, err := LoadKeyStore(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Error(err)
+	}
+	if len(store.List()) != 0 {
+		t.Error("Expected empty key store for missing file")
+	}
+}
@@ -0,0 +1,61 @@
+package pairing
+
+import (
+	"encoding/base64"
+)
+
+// pairingData holds the state needed to carry out one side of a pairing
+// exchange: who we are, who we're pairing with, and the keys involved.
+type pairingData struct {
+	pairingId       string
+	role            string
+	serverToken     string
+	remotePublicKey PublicKey
+	localKeyPair    KeyPair
+}
+
+// PairingTokenGenerator produces and verifies the signed messages exchanged
+// between two peers while pairing.
+type PairingTokenGenerator struct {
+	pairingData
+}
+
+// GetPairingId returns the identifier shared by both sides of the pairing
+// exchange.
+func (p PairingTokenGenerator) GetPairingId() string {
+	return p.pairingId
+}
+
+// GetRole returns this side's role in the pairing exchange (e.g. "initiator"
+// or "responder").
+func (p PairingTokenGenerator) GetRole() string {
+	return p.role
+}
+
+// GenerateToken returns the server token to be used for this pairing
+// exchange.
+func (p PairingTokenGenerator) GenerateToken() string {
+	return p.serverToken
+}
+
+// SignMessage signs the given message with the local private key, returning
+// the signature base64-encoded.
+func (p PairingTokenGenerator) SignMessage(message string) (string, error) {
+	signature, err := p.localKeyPair.PrivateKey.signMessage(message)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(signature), nil
+}
+
+// VerifyMessage verifies a base64-encoded signature against the given
+// message, using the remote peer's public key.
+func (p PairingTokenGenerator) VerifyMessage(signature string, message string) bool {
+	signatureBytes, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	return p.remotePublicKey.verifyMessage(signatureBytes, message)
+}
@@ -0,0 +1,22 @@
+package pairing
+
+import (
+	"io"
+)
+
+// constReader is an io.Reader which always yields the same byte value,
+// useful for producing deterministic key material and signatures in tests.
+type constReader struct {
+	b byte
+}
+
+func (c constReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = c.b
+	}
+	return len(p), nil
+}
+
+// onesReader is a constReader fixed at 1, used as the default deterministic
+// randomness source across tests in this package.
+var onesReader io.Reader = constReader{1}
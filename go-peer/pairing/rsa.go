@@ -0,0 +1,192 @@
+package pairing
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+)
+
+// DefaultRsaKeyBits is the key size used by NewRsaKeyOperations.
+const DefaultRsaKeyBits = 2048
+
+type rsaKeyOperations struct {
+	rand io.Reader
+	bits int
+}
+
+// Returns a KeyOperations which implements RSA public/private keypairs at
+// DefaultRsaKeyBits, signed with RS256.
+func NewRsaKeyOperations() KeyOperations {
+	return NewRsaKeyOperationsWithRand(DefaultRsaKeyBits, rand.Reader)
+}
+
+// Returns a KeyOperations which implements RSA public/private keypairs of the
+// given bit size, signed with RS256.
+func NewRsaKeyOperationsWithBits(bits int) KeyOperations {
+	return NewRsaKeyOperationsWithRand(bits, rand.Reader)
+}
+
+func NewRsaKeyOperationsWithRand(bits int, rand io.Reader) KeyOperations {
+	return rsaKeyOperations{rand, bits}
+}
+
+// Imports a JWK-encoded RSA public key into our PublicKey representation.
+func (rsaKeyOperations) importJwkPublicKey(jwk string) (key PublicKey, err error) {
+	members := struct {
+		Kty string
+		N   string
+		E   string
+	}{}
+	err = json.Unmarshal([]byte(jwk), &members)
+	if err != nil {
+		return
+	}
+
+	if members.Kty != "RSA" {
+		err = fmt.Errorf("JWK algorithm %v is not acceptable", members.Kty)
+		return
+	}
+
+	n, err := stringToBigInt(members.N)
+	if err != nil {
+		return
+	}
+	e, err := stringToBigInt(members.E)
+	if err != nil {
+		return
+	}
+
+	key = rsaPublicKey{
+		&rsa.PublicKey{
+			N: n,
+			E: int(e.Int64()),
+		},
+	}
+	return
+}
+
+func (r rsaKeyOperations) importJwkPrivateKey(data string) (PrivateKey, error) {
+	members := struct {
+		Kty string
+		N   string
+		E   string
+		D   string
+		P   string
+		Q   string
+	}{}
+	err := json.Unmarshal([]byte(data), &members)
+	if err != nil {
+		return nil, err
+	}
+
+	if members.Kty != "RSA" {
+		return nil, fmt.Errorf("JWK algorithm %v is not acceptable", members.Kty)
+	}
+
+	n, err := stringToBigInt(members.N)
+	if err != nil {
+		return nil, err
+	}
+	e, err := stringToBigInt(members.E)
+	if err != nil {
+		return nil, err
+	}
+	d, err := stringToBigInt(members.D)
+	if err != nil {
+		return nil, err
+	}
+	p, err := stringToBigInt(members.P)
+	if err != nil {
+		return nil, err
+	}
+	q, err := stringToBigInt(members.Q)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey := &rsa.PrivateKey{
+		PublicKey: rsa.PublicKey{
+			N: n,
+			E: int(e.Int64()),
+		},
+		D:      d,
+		Primes: []*big.Int{p, q},
+	}
+	privateKey.Precompute()
+
+	return rsaPrivateKey{privateKey, r.rand}, nil
+}
+
+// Generates an RSA key pair at the configured bit size.
+func (r rsaKeyOperations) generateKeyPair() (keyPair KeyPair, err error) {
+	privateKey, err := rsa.GenerateKey(r.rand, r.bits)
+	if err != nil {
+		return
+	}
+
+	keyPair = KeyPair{
+		PublicKey:  rsaPublicKey{&privateKey.PublicKey},
+		PrivateKey: rsaPrivateKey{privateKey, r.rand},
+	}
+	return
+}
+
+type rsaPublicKey struct {
+	publicKey *rsa.PublicKey
+}
+
+type rsaPrivateKey struct {
+	privateKey *rsa.PrivateKey
+	rand       io.Reader
+}
+
+func (r rsaPublicKey) verifyMessage(signature []byte, message string) bool {
+	hash := sha256.Sum256([]byte(message))
+	return rsa.VerifyPKCS1v15(r.publicKey, crypto.SHA256, hash[:], signature) == nil
+}
+
+func (r rsaPublicKey) exportJwk() string {
+	members := struct {
+		Kty string `json:"kty"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+	}{
+		Kty: "RSA",
+		N:   bigIntToString(r.publicKey.N),
+		E:   bigIntToString(big.NewInt(int64(r.publicKey.E))),
+	}
+
+	jwk, _ := json.Marshal(members)
+	return string(jwk)
+}
+
+func (r rsaPrivateKey) signMessage(message string) ([]byte, error) {
+	hash := sha256.Sum256([]byte(message))
+	return rsa.SignPKCS1v15(r.rand, r.privateKey, crypto.SHA256, hash[:])
+}
+
+func (r rsaPrivateKey) exportJwk() string {
+	members := struct {
+		Kty string `json:"kty"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+		D   string `json:"d"`
+		P   string `json:"p"`
+		Q   string `json:"q"`
+	}{
+		Kty: "RSA",
+		N:   bigIntToString(r.privateKey.N),
+		E:   bigIntToString(big.NewInt(int64(r.privateKey.E))),
+		D:   bigIntToString(r.privateKey.D),
+		P:   bigIntToString(r.privateKey.Primes[0]),
+		Q:   bigIntToString(r.privateKey.Primes[1]),
+	}
+
+	jwk, _ := json.Marshal(members)
+	return string(jwk)
+}
@@ -0,0 +1,68 @@
+package pairing
+
+import (
+	"testing"
+)
+
+func TestRsaGenerateFullRoundTrip(t *testing.T) {
+	keyOperations := NewRsaKeyOperationsWithBits(2048)
+
+	keyPair, err := keyOperations.generateKeyPair()
+	if err != nil {
+		t.Error(err)
+	}
+
+	exportedPublicKey := keyPair.PublicKey.exportJwk()
+	importedPublicKey, err := keyOperations.importJwkPublicKey(exportedPublicKey)
+	if err != nil {
+		t.Error(err)
+	}
+
+	exportedPrivateKey := keyPair.PrivateKey.exportJwk()
+	importedPrivateKey, err := keyOperations.importJwkPrivateKey(exportedPrivateKey)
+	if err != nil {
+		t.Error(err)
+	}
+
+	signature, err := importedPrivateKey.signMessage("hello")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !importedPublicKey.verifyMessage(signature, "hello") {
+		t.Error("Failed to verify own signature")
+	}
+}
+
+func TestRsaPublicKeyImportInvalidAlgorithm(t *testing.T) {
+	keyOperations := NewRsaKeyOperations()
+
+	_, err := keyOperations.importJwkPublicKey(`
+	{
+		"kty": "EC",
+		"n": "0",
+		"e": "0"
+	}
+	`)
+	if err == nil {
+		t.Error("Failed to raise error on invalid algorithm.")
+	}
+}
+
+func TestRsaVerifyRejectsTamperedMessage(t *testing.T) {
+	keyOperations := NewRsaKeyOperationsWithBits(2048)
+
+	keyPair, err := keyOperations.generateKeyPair()
+	if err != nil {
+		t.Error(err)
+	}
+
+	signature, err := keyPair.PrivateKey.signMessage("hello")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if keyPair.PublicKey.verifyMessage(signature, "goodbye") {
+		t.Error("Verified incorrect message")
+	}
+}